@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wangxu-dev/mcp-skill-registry/internal/gitclient"
+)
+
+// skillChanges is the machine-readable form of a single skill's change
+// between two index updates, written to skill/<name>/skill.changes.json
+// alongside a human-readable skill/<name>/CHANGELOG.md.
+type skillChanges struct {
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Files   []gitclient.FileDiff `json:"files"`
+	Summary string               `json:"summary"`
+}
+
+// changeEntry is one line of the top-level changes.skill.json roll-up.
+type changeEntry struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // "added", "updated", or "removed"
+	OldHead string `json:"oldHead,omitempty"`
+	NewHead string `json:"newHead,omitempty"`
+}
+
+type changesRollup struct {
+	Schema      string        `json:"$schema,omitempty"`
+	GeneratedAt string        `json:"generatedAt,omitempty"`
+	Changes     []changeEntry `json:"changes"`
+}
+
+// writeSkillChangelog records a skill's diff between two heads at
+// skillPath, as both a unified-diff changelog for humans and a
+// structured skill.changes.json for tooling.
+func writeSkillChangelog(skillPath string, changes skillChanges, unified string) error {
+	data, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(filepath.Join(skillPath, "skill.changes.json"), data, 0644); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Changelog\n\n")
+	fmt.Fprintf(&b, "%s\n\n", changes.Summary)
+	fmt.Fprintf(&b, "`%s` -> `%s`\n\n", shortHead(changes.From), shortHead(changes.To))
+	if len(changes.Files) > 0 {
+		b.WriteString("| File | Added | Removed |\n")
+		b.WriteString("| --- | ---: | ---: |\n")
+		for _, f := range changes.Files {
+			fmt.Fprintf(&b, "| %s | +%d | -%d |\n", f.Path, f.Added, f.Removed)
+		}
+		b.WriteString("\n")
+	}
+	if unified != "" {
+		b.WriteString("```diff\n")
+		b.WriteString(strings.TrimSuffix(unified, "\n"))
+		b.WriteString("\n```\n")
+	}
+	return os.WriteFile(filepath.Join(skillPath, "CHANGELOG.md"), []byte(b.String()), 0644)
+}
+
+// buildSkillChangelog diffs a skill's SourcePath between fromHead and
+// toHead within the repo checked out at dest, and writes the result to
+// skillPath. A skill with no prior head (a brand-new skill) has no
+// diff to compute, so callers should only call this for updates.
+func buildSkillChangelog(backend gitclient.Backend, auth gitclient.Auth, dest, repo, sourcePath, skillPath, fromHead, toHead string, maxDiffBytes int) error {
+	result, err := backend.Diff(dest, repo, fromHead, toHead, sourcePath, auth, maxDiffBytes)
+	if err != nil {
+		return fmt.Errorf("diffing %q between %s and %s: %w", sourcePath, fromHead, toHead, err)
+	}
+	changes := skillChanges{
+		From:    fromHead,
+		To:      toHead,
+		Files:   result.Files,
+		Summary: fmt.Sprintf("%d file(s) changed", len(result.Files)),
+	}
+	if result.Unified != "" {
+		if err := os.WriteFile(filepath.Join(skillPath, "skill.diff.patch"), []byte(result.Unified), 0644); err != nil {
+			return err
+		}
+	}
+	return writeSkillChangelog(skillPath, changes, result.Unified)
+}
+
+func shortHead(head string) string {
+	if len(head) > 12 {
+		return head[:12]
+	}
+	return head
+}
+
+// writeChangesRollup writes the top-level changes.skill.json feed next
+// to indexPath, listing every skill added, updated, or removed in this
+// run so registry consumers can subscribe to a stable change feed
+// without diffing index.skill.json themselves.
+func writeChangesRollup(indexPath string, generatedAt string, entries []changeEntry) error {
+	rollup := changesRollup{
+		GeneratedAt: generatedAt,
+		Changes:     entries,
+	}
+	data, err := json.MarshalIndent(rollup, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	path := filepath.Join(filepath.Dir(indexPath), "changes.skill.json")
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wangxu-dev/mcp-skill-registry/internal/gitclient"
+)
+
+// lfsMode controls how Git LFS pointer files encountered while mirroring
+// a skill are handled.
+type lfsMode string
+
+const (
+	lfsAuto    lfsMode = "auto"    // resolve pointers, leave unresolved ones in place on failure
+	lfsOff     lfsMode = "off"     // never resolve; copy pointer files verbatim
+	lfsRequire lfsMode = "require" // abort the run if any pointer fails to resolve
+)
+
+func parseLFSMode(s string) (lfsMode, error) {
+	switch lfsMode(s) {
+	case "", lfsAuto:
+		return lfsAuto, nil
+	case lfsOff:
+		return lfsOff, nil
+	case lfsRequire:
+		return lfsRequire, nil
+	default:
+		return "", fmt.Errorf("invalid -lfs value %q: want auto, off, or require", s)
+	}
+}
+
+// lfsConfig carries everything copyFile needs to resolve an LFS pointer
+// it encounters: which mode to apply and which repo/credentials to fetch
+// the object from.
+type lfsConfig struct {
+	Mode lfsMode
+	Repo string
+	Auth gitclient.Auth
+}
+
+const lfsPointerSignature = "version https://git-lfs.github.com/spec/v1"
+
+// parseLFSPointer recognizes the small text file git-lfs leaves in place
+// of a tracked binary, e.g.:
+//
+//	version https://git-lfs.github.com/spec/v1
+//	oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+//	size 12345
+func parseLFSPointer(data []byte) (oid string, size int64, ok bool) {
+	if len(data) > 1024 || !bytes.HasPrefix(data, []byte(lfsPointerSignature)) {
+		return "", 0, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "oid sha256:"):
+			oid = strings.TrimPrefix(line, "oid sha256:")
+		case strings.HasPrefix(line, "size "):
+			n, err := strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64)
+			if err != nil {
+				return "", 0, false
+			}
+			size = n
+		}
+	}
+	return oid, size, oid != "" && size > 0
+}
+
+// findUnresolvedLFSPointers walks an already-mirrored skill directory and
+// reports the dst-relative paths of any files still in raw LFS pointer
+// form, for recomputing lfsMetaStatus without a source repo checkout to
+// re-copy from (e.g. the "repo unchanged" fast path in run).
+func findUnresolvedLFSPointers(dir string) ([]string, error) {
+	var unresolved []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, _, ok := parseLFSPointer(data); ok {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			unresolved = append(unresolved, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unresolved, nil
+}
+
+type lfsBatchRequest struct {
+	Operation string         `json:"operation"`
+	Transfers []string       `json:"transfers"`
+	Objects   []lfsObjectReq `json:"objects"`
+}
+
+type lfsObjectReq struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type lfsBatchResponse struct {
+	Objects []struct {
+		OID   string `json:"oid"`
+		Size  int64  `json:"size"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error,omitempty"`
+		Actions struct {
+			Download struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header,omitempty"`
+			} `json:"download"`
+		} `json:"actions"`
+	} `json:"objects"`
+}
+
+// fetchLFSObject resolves oid/size to its actual content via the LFS
+// batch API (https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md),
+// using the credentials configured for cfg.Repo, and verifies the
+// downloaded bytes hash to oid before returning them.
+func fetchLFSObject(cfg lfsConfig, oid string, size int64) ([]byte, error) {
+	repo := strings.TrimSuffix(cfg.Repo, "/")
+	repo = strings.TrimSuffix(repo, ".git")
+	endpoint := repo + ".git/info/lfs/objects/batch"
+
+	reqBody, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   []lfsObjectReq{{OID: oid, Size: size}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.git-lfs+json")
+	req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+	applyLFSAuth(req, cfg.Auth)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("lfs batch request to %q: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lfs batch request to %q: unexpected status %d", endpoint, resp.StatusCode)
+	}
+
+	var batch lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, fmt.Errorf("decoding lfs batch response: %w", err)
+	}
+	if len(batch.Objects) == 0 {
+		return nil, fmt.Errorf("lfs batch response for %q contained no objects", oid)
+	}
+	obj := batch.Objects[0]
+	if obj.Error != nil {
+		return nil, fmt.Errorf("lfs batch error for %q: %s (code %d)", oid, obj.Error.Message, obj.Error.Code)
+	}
+
+	downloadReq, err := http.NewRequest(http.MethodGet, obj.Actions.Download.Href, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range obj.Actions.Download.Header {
+		downloadReq.Header.Set(k, v)
+	}
+	downloadResp, err := http.DefaultClient.Do(downloadReq)
+	if err != nil {
+		return nil, fmt.Errorf("downloading lfs object %q: %w", oid, err)
+	}
+	defer downloadResp.Body.Close()
+	if downloadResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading lfs object %q: unexpected status %d", oid, downloadResp.StatusCode)
+	}
+
+	data, err := io.ReadAll(downloadResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != oid {
+		return nil, fmt.Errorf("lfs object %q failed checksum verification", oid)
+	}
+	return data, nil
+}
+
+func applyLFSAuth(req *http.Request, auth gitclient.Auth) {
+	if auth.HTTPToken != "" {
+		req.Header.Set("Authorization", "Bearer "+auth.HTTPToken)
+	}
+}
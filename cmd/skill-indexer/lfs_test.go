@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wangxu-dev/mcp-skill-registry/internal/gitclient"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantOID  string
+		wantSize int64
+		wantOK   bool
+	}{
+		{
+			name: "valid pointer",
+			data: "version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e239\n" +
+				"size 12345\n",
+			wantOID:  "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e239",
+			wantSize: 12345,
+			wantOK:   true,
+		},
+		{
+			name:   "not a pointer",
+			data:   "#!/usr/bin/env bash\necho hello\n",
+			wantOK: false,
+		},
+		{
+			name: "missing oid",
+			data: "version https://git-lfs.github.com/spec/v1\n" +
+				"size 12345\n",
+			wantOK: false,
+		},
+		{
+			name: "missing size",
+			data: "version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e239\n",
+			wantOK: false,
+		},
+		{
+			name: "zero size",
+			data: "version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e239\n" +
+				"size 0\n",
+			wantOK: false,
+		},
+		{
+			name:   "too large to be a pointer",
+			data:   strings.Repeat("x", 2000),
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oid, size, ok := parseLFSPointer([]byte(tt.data))
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if oid != tt.wantOID {
+				t.Errorf("oid = %q, want %q", oid, tt.wantOID)
+			}
+			if size != tt.wantSize {
+				t.Errorf("size = %d, want %d", size, tt.wantSize)
+			}
+		})
+	}
+}
+
+// TestFetchLFSObjectEndpoint verifies fetchLFSObject hits exactly one
+// /info/lfs/objects/batch segment even when cfg.Repo already ends in
+// .git, and that the downloaded bytes are checksum-verified against oid.
+func TestFetchLFSObjectEndpoint(t *testing.T) {
+	content := []byte("hello lfs object")
+	sum := sha256.Sum256(content)
+	oid := hex.EncodeToString(sum[:])
+
+	var gotBatchPath string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/org/repo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		gotBatchPath = r.URL.Path
+		downloadHref := "http://" + r.Host + "/blob"
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"objects": []map[string]any{{
+				"oid":  oid,
+				"size": len(content),
+				"actions": map[string]any{
+					"download": map[string]any{"href": downloadHref},
+				},
+			}},
+		})
+	})
+	mux.HandleFunc("/blob", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := lfsConfig{Mode: lfsAuto, Repo: srv.URL + "/org/repo.git", Auth: gitclient.Auth{}}
+	data, err := fetchLFSObject(cfg, oid, int64(len(content)))
+	if err != nil {
+		t.Fatalf("fetchLFSObject: %v", err)
+	}
+	if string(data) != string(content) {
+		t.Errorf("data = %q, want %q", data, content)
+	}
+	if gotBatchPath != "/org/repo.git/info/lfs/objects/batch" {
+		t.Errorf("batch endpoint hit %q, want exactly one /info/lfs/objects/batch (no doubled .git)", gotBatchPath)
+	}
+}
+
+func TestFetchLFSObjectChecksumMismatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/org/repo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		downloadHref := "http://" + r.Host + "/blob"
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"objects": []map[string]any{{
+				"oid":  "deadbeef",
+				"size": 5,
+				"actions": map[string]any{
+					"download": map[string]any{"href": downloadHref},
+				},
+			}},
+		})
+	})
+	mux.HandleFunc("/blob", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("wrong"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := lfsConfig{Mode: lfsAuto, Repo: srv.URL + "/org/repo.git", Auth: gitclient.Auth{}}
+	if _, err := fetchLFSObject(cfg, "deadbeef", 5); err == nil {
+		t.Fatal("expected checksum verification failure, got nil error")
+	}
+}
@@ -7,12 +7,13 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/wangxu-dev/mcp-skill-registry/internal/gitclient"
 )
 
 type sourcesFile struct {
@@ -21,9 +22,11 @@ type sourcesFile struct {
 }
 
 type source struct {
-	Repo    string   `json:"repo"`
-	Branch  string   `json:"branch,omitempty"`
-	Exclude []string `json:"exclude,omitempty"`
+	Repo              string   `json:"repo"`
+	Branch            string   `json:"branch,omitempty"`
+	Exclude           []string `json:"exclude,omitempty"`
+	RecurseSubmodules bool     `json:"recurseSubmodules,omitempty"`
+	LFS               string   `json:"lfs,omitempty"`
 }
 
 type indexFile struct {
@@ -43,15 +46,42 @@ type skill struct {
 type foundSkill struct {
 	Name       string
 	SourcePath string
+	// Head is the commit SHA the skill's content actually came from. It
+	// is empty for skills found directly in the source repo (callers
+	// should use the repo's own head) and set to the submodule's head
+	// for skills discovered by recursing into one.
+	Head string
+	// Submodules records the chain of submodules (outermost first) a
+	// skill was found nested inside, for provenance reporting.
+	Submodules []submoduleProvenance
+}
+
+// submoduleProvenance identifies the submodule a skill's content came
+// from, so downstream consumers can audit exactly which upstream commit
+// produced it without cloning the source repo themselves.
+type submoduleProvenance struct {
+	Path string `json:"path"`
+	Repo string `json:"repo"`
+	Head string `json:"head"`
 }
 
 type skillMeta struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	Version     string `json:"version,omitempty"`
-	Head        string `json:"head,omitempty"`
-	UpdatedAt   string `json:"updatedAt,omitempty"`
-	CheckedAt   string `json:"checkedAt,omitempty"`
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Version     string                `json:"version,omitempty"`
+	Head        string                `json:"head,omitempty"`
+	UpdatedAt   string                `json:"updatedAt,omitempty"`
+	CheckedAt   string                `json:"checkedAt,omitempty"`
+	Submodules  []submoduleProvenance `json:"submodules,omitempty"`
+	LFS         *lfsMetaStatus        `json:"lfs,omitempty"`
+	Provenance  *skillProvenance      `json:"provenance,omitempty"`
+}
+
+// lfsMetaStatus records any Git LFS pointer files that could not be
+// resolved to their real content while mirroring a skill (only possible
+// in `-lfs=auto` mode; `-lfs=require` aborts the run instead).
+type lfsMetaStatus struct {
+	Unresolved []string `json:"unresolved,omitempty"`
 }
 
 var defaultExclude = []string{
@@ -76,20 +106,39 @@ var defaultExclude = []string{
 
 func main() {
 	var (
-		sourcesPath = flag.String("sources", "sources.skill.json", "path to sources.skill.json")
-		indexPath   = flag.String("index", "index.skill.json", "path to index.skill.json")
-		sourcesDir  = flag.String("sources-dir", "sources", "directory to clone sources into")
-		keepSources = flag.Bool("keep-sources", false, "keep cloned repos after update")
+		sourcesPath  = flag.String("sources", "sources.skill.json", "path to sources.skill.json")
+		indexPath    = flag.String("index", "index.skill.json", "path to index.skill.json")
+		sourcesDir   = flag.String("sources-dir", "sources", "directory to clone sources into")
+		keepSources  = flag.Bool("keep-sources", false, "keep cloned repos after update")
+		gitBackend   = flag.String("git-backend", string(gitclient.Exec), "git backend to use: exec|go-git")
+		sshKey       = flag.String("ssh-key", "", "path to an SSH private key to use for git@/ssh:// remotes")
+		lfsFlag      = flag.String("lfs", string(lfsAuto), "how to resolve Git LFS pointer files: auto|off|require")
+		maxDiffBytes = flag.Int("max-diff-bytes", 200_000, "truncate a skill's unified diff after this many bytes (0 for unlimited)")
 	)
 	flag.Parse()
 
-	if err := run(*sourcesPath, *indexPath, *sourcesDir, *keepSources); err != nil {
+	backend, err := gitclient.New(gitclient.Name(*gitBackend))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	auth := gitclient.Auth{
+		SSHKeyPath: *sshKey,
+		HTTPToken:  os.Getenv("SKILL_REGISTRY_HTTP_TOKEN"),
+	}
+	defaultLFSMode, err := parseLFSMode(*lfsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := run(backend, auth, defaultLFSMode, *sourcesPath, *indexPath, *sourcesDir, *keepSources, *maxDiffBytes); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
+func run(backend gitclient.Backend, auth gitclient.Auth, defaultLFSMode lfsMode, sourcesPath, indexPath, sourcesDir string, keepSources bool, maxDiffBytes int) error {
 	src, err := loadSources(sourcesPath)
 	if err != nil {
 		return err
@@ -103,6 +152,12 @@ func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
 		return err
 	}
 
+	blameCache, err := loadBlameCache(blameCachePath)
+	if err != nil {
+		return err
+	}
+	blameCacheDirty := false
+
 	existingByRepo := map[string][]skill{}
 	existingHead := map[string]string{}
 	for _, s := range existing.Skills {
@@ -137,20 +192,41 @@ func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
 		}
 		repoNameSeen[repoName] = repo
 
-		head, err := gitRemoteHead(repo, srcRepo.Branch)
+		head, err := backend.RemoteHead(repo, srcRepo.Branch, auth)
 		if err != nil {
 			return err
 		}
 
+		mode := defaultLFSMode
+		if srcRepo.LFS != "" {
+			parsed, err := parseLFSMode(srcRepo.LFS)
+			if err != nil {
+				return fmt.Errorf("source %q: %w", repo, err)
+			}
+			mode = parsed
+		}
+
 		if existingHead[repo] == head && head != "" && !needsSourcePathUpdate(existingByRepo[repo]) {
 			for _, s := range existingByRepo[repo] {
 				updatedSkills = append(updatedSkills, s)
 				pathOwners[destPathForName(s.Name)] = repo
+
+				var lfsStatus *lfsMetaStatus
+				unresolved, err := findUnresolvedLFSPointers(destPathForName(s.Name))
+				if err != nil {
+					return err
+				}
+				if len(unresolved) > 0 {
+					lfsStatus = &lfsMetaStatus{Unresolved: unresolved}
+				}
+
 				meta := skillMeta{
-					Name:      s.Name,
-					Head:      s.Head,
-					UpdatedAt: s.UpdatedAt,
-					CheckedAt: now,
+					Name:       s.Name,
+					Head:       s.Head,
+					UpdatedAt:  s.UpdatedAt,
+					CheckedAt:  now,
+					LFS:        lfsStatus,
+					Provenance: provenanceFromCacheOnly(blameCache, repo, s.Head, s.Path, destPathForName(s.Name)),
 				}
 				if err := enrichMetaFromSkill(destPathForName(s.Name), &meta); err != nil {
 					return err
@@ -162,7 +238,7 @@ func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
 			continue
 		}
 
-		repoSkills, actualHead, err := scanRepo(repo, srcRepo.Branch, sourcesDir, repoName, srcRepo.Exclude)
+		repoSkills, actualHead, err := scanRepo(backend, auth, repo, srcRepo.Branch, sourcesDir, repoName, srcRepo.Exclude, srcRepo.RecurseSubmodules)
 		if err != nil {
 			return err
 		}
@@ -187,26 +263,54 @@ func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
 			return err
 		}
 
-		if err := mirrorSkills("skill", filepath.Join(sourcesDir, repoName), repoSkills); err != nil {
+		mode := defaultLFSMode
+		if srcRepo.LFS != "" {
+			parsed, err := parseLFSMode(srcRepo.LFS)
+			if err != nil {
+				return fmt.Errorf("source %q: %w", repo, err)
+			}
+			mode = parsed
+		}
+		lfsCfg := lfsConfig{Mode: mode, Repo: repo, Auth: auth}
+		dest := filepath.Join(sourcesDir, repoName)
+
+		unresolvedByName, err := mirrorSkills("skill", dest, repoSkills, lfsCfg)
+		if err != nil {
 			return err
 		}
 
 		for _, rs := range repoSkills {
 			destPath := destPathForName(rs.Name)
 			pathOwners[destPath] = repo
+			head := actualHead
+			if rs.Head != "" {
+				head = rs.Head
+			}
 			entry := skill{
 				Name:      rs.Name,
 				Path:      rs.SourcePath,
 				Repo:      repo,
-				Head:      actualHead,
+				Head:      head,
 				UpdatedAt: now,
 			}
 			updatedSkills = append(updatedSkills, entry)
+			var lfsStatus *lfsMetaStatus
+			if unresolved := unresolvedByName[rs.Name]; len(unresolved) > 0 {
+				lfsStatus = &lfsMetaStatus{Unresolved: unresolved}
+			}
+			provenance, dirty, err := computeSkillProvenance(backend, auth, blameCache, dest, repo, head, rs.SourcePath, filepath.Join(dest, filepath.FromSlash(rs.SourcePath)))
+			if err != nil {
+				return err
+			}
+			blameCacheDirty = blameCacheDirty || dirty
 			meta := skillMeta{
-				Name:      rs.Name,
-				Head:      entry.Head,
-				UpdatedAt: entry.UpdatedAt,
-				CheckedAt: now,
+				Name:       rs.Name,
+				Head:       entry.Head,
+				UpdatedAt:  entry.UpdatedAt,
+				LFS:        lfsStatus,
+				CheckedAt:  now,
+				Submodules: rs.Submodules,
+				Provenance: provenance,
 			}
 			if err := enrichMetaFromSkill(destPathForName(rs.Name), &meta); err != nil {
 				return err
@@ -216,11 +320,36 @@ func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
 			}
 		}
 
+		existingSkillByName := map[string]skill{}
+		for _, s := range existingByRepo[repo] {
+			existingSkillByName[s.Name] = s
+		}
+		for _, rs := range repoSkills {
+			head := actualHead
+			if rs.Head != "" {
+				head = rs.Head
+			}
+			old, ok := existingSkillByName[rs.Name]
+			if !ok || old.Head == "" || old.Head == head {
+				continue
+			}
+			skillPath := destPathForName(rs.Name)
+			if err := buildSkillChangelog(backend, auth, dest, repo, rs.SourcePath, skillPath, old.Head, head, maxDiffBytes); err != nil {
+				return err
+			}
+		}
+
 		if !keepSources {
 			_ = os.RemoveAll(filepath.Join(sourcesDir, repoName))
 		}
 	}
 
+	if blameCacheDirty {
+		if err := saveBlameCache(blameCachePath, blameCache); err != nil {
+			return err
+		}
+	}
+
 	sort.Slice(updatedSkills, func(i, j int) bool {
 		if updatedSkills[i].Repo != updatedSkills[j].Repo {
 			return updatedSkills[i].Repo < updatedSkills[j].Repo
@@ -238,12 +367,50 @@ func run(sourcesPath, indexPath, sourcesDir string, keepSources bool) error {
 
 	if !reflect.DeepEqual(existing.Skills, newIndex.Skills) || !reflect.DeepEqual(existing.Schema, newIndex.Schema) {
 		newIndex.GeneratedAt = now
+		if err := writeChangesRollup(indexPath, now, diffSkillLists(existing.Skills, newIndex.Skills)); err != nil {
+			return err
+		}
 		return writeIndex(indexPath, newIndex)
 	}
 
 	return nil
 }
 
+// diffSkillLists classifies every skill in before/after as added,
+// updated (same name, different head), or removed, for the top-level
+// changes.skill.json feed.
+func diffSkillLists(before, after []skill) []changeEntry {
+	beforeByName := map[string]skill{}
+	for _, s := range before {
+		beforeByName[s.Name] = s
+	}
+	afterByName := map[string]skill{}
+	for _, s := range after {
+		afterByName[s.Name] = s
+	}
+
+	var entries []changeEntry
+	for _, s := range after {
+		old, existed := beforeByName[s.Name]
+		switch {
+		case !existed:
+			entries = append(entries, changeEntry{Name: s.Name, Status: "added", NewHead: s.Head})
+		case old.Head != s.Head:
+			entries = append(entries, changeEntry{Name: s.Name, Status: "updated", OldHead: old.Head, NewHead: s.Head})
+		}
+	}
+	for _, s := range before {
+		if _, stillPresent := afterByName[s.Name]; !stillPresent {
+			entries = append(entries, changeEntry{Name: s.Name, Status: "removed", OldHead: s.Head})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
 func loadSources(path string) (sourcesFile, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -280,74 +447,36 @@ func writeIndex(path string, idx indexFile) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-func gitRemoteHead(repo, branch string) (string, error) {
-	ref := branch
-	if ref == "" {
-		ref = "HEAD"
-	}
-	out, err := runGit("", "ls-remote", repo, ref)
-	if err != nil {
-		return "", err
-	}
-	fields := strings.Fields(out)
-	if len(fields) == 0 {
-		return "", fmt.Errorf("git ls-remote returned no data for %q %q", repo, ref)
-	}
-	return fields[0], nil
-}
-
-func scanRepo(repo, branch, sourcesDir, repoName string, extraExclude []string) ([]foundSkill, string, error) {
+func scanRepo(backend gitclient.Backend, auth gitclient.Auth, repo, branch, sourcesDir, repoName string, extraExclude []string, recurseSubmodules bool) ([]foundSkill, string, error) {
 	if err := os.MkdirAll(sourcesDir, 0755); err != nil {
 		return nil, "", err
 	}
 	dest := filepath.Join(sourcesDir, repoName)
-	_ = os.RemoveAll(dest)
 
-	cloneArgs := []string{"clone", "--depth", "1"}
-	if branch != "" {
-		cloneArgs = append(cloneArgs, "--branch", branch)
+	head, err := backend.Checkout(repo, gitclient.CloneOptions{Branch: branch, Dest: dest, Auth: auth})
+	if err != nil {
+		return nil, "", err
 	}
-	cloneArgs = append(cloneArgs, repo, dest)
-	if _, err := runGit("", cloneArgs...); err != nil {
+
+	excludeSet := buildExcludeSet(extraExclude)
+	vcsFilter, err := loadVCSFilter(dest)
+	if err != nil {
 		return nil, "", err
 	}
 
-	head, err := runGit(dest, "rev-parse", "HEAD")
+	skills, err := walkSkills(dest, dest, excludeSet, vcsFilter)
 	if err != nil {
 		return nil, "", err
 	}
-	head = strings.TrimSpace(head)
 
-	excludeSet := buildExcludeSet(extraExclude)
-	var skills []foundSkill
-	err = filepath.WalkDir(dest, func(path string, d fs.DirEntry, err error) error {
+	if recurseSubmodules {
+		subSkills, err := scanSubmodules(dest, auth)
 		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			name := d.Name()
-			if shouldSkipDir(name, excludeSet) {
-				return fs.SkipDir
-			}
-			return nil
+			return nil, "", err
 		}
-		if strings.EqualFold(d.Name(), "SKILL.md") {
-			dir := filepath.Dir(path)
-			rel, err := filepath.Rel(dest, dir)
-			if err != nil {
-				return err
-			}
-			rel = filepath.ToSlash(rel)
-			skills = append(skills, foundSkill{
-				Name:       filepath.Base(dir),
-				SourcePath: rel,
-			})
-		}
-		return nil
-	})
-	if err != nil {
-		return nil, "", err
+		skills = append(skills, subSkills...)
 	}
+
 	if len(skills) == 0 {
 		return nil, head, nil
 	}
@@ -362,6 +491,56 @@ func scanRepo(repo, branch, sourcesDir, repoName string, extraExclude []string)
 	return skills, head, nil
 }
 
+// walkSkills finds SKILL.md directories under root (a repo or submodule
+// checkout at dest), skipping anything excluded by name, .gitignore, or
+// a `skill-registry=exclude` .gitattributes entry.
+func walkSkills(dest, root string, excludeSet map[string]bool, vcsFilter *vcsFilter) ([]foundSkill, error) {
+	var skills []foundSkill
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		rel, relErr := filepath.Rel(dest, path)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if shouldSkipDir(d.Name(), excludeSet) {
+				return fs.SkipDir
+			}
+			if vcsFilter.excludes(rel, true) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(d.Name(), "SKILL.md") {
+			return nil
+		}
+		if vcsFilter.excludes(rel, false) {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		dirRel, err := filepath.Rel(dest, dir)
+		if err != nil {
+			return err
+		}
+		skills = append(skills, foundSkill{
+			Name:       filepath.Base(dir),
+			SourcePath: filepath.ToSlash(dirRel),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
 func buildExcludeSet(extra []string) map[string]bool {
 	set := map[string]bool{}
 	for _, name := range defaultExclude {
@@ -384,23 +563,6 @@ func shouldSkipDir(name string, exclude map[string]bool) bool {
 	return exclude[strings.ToLower(name)]
 }
 
-func runGit(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	if dir != "" {
-		cmd.Dir = dir
-	}
-	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		msg := strings.TrimSpace(string(out))
-		if msg == "" {
-			msg = err.Error()
-		}
-		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
-	}
-	return strings.TrimSpace(string(out)), nil
-}
-
 func repoFolderName(repo string) string {
 	repo = strings.TrimSpace(repo)
 	repo = strings.TrimSuffix(repo, "/")
@@ -445,28 +607,37 @@ func removeRepoSkills(entries []skill) error {
 	return nil
 }
 
-func mirrorSkills(skillRoot, repoDir string, entries []foundSkill) error {
+// mirrorSkills copies each entry's source directory into skillRoot. It
+// returns any LFS pointer files that could not be resolved to their real
+// content, keyed by skill name (always empty unless lfsCfg.Mode is
+// lfsAuto and a download failed).
+func mirrorSkills(skillRoot, repoDir string, entries []foundSkill, lfsCfg lfsConfig) (map[string][]string, error) {
 	if err := os.MkdirAll(skillRoot, 0755); err != nil {
-		return err
+		return nil, err
 	}
+	unresolvedByName := map[string][]string{}
 	seen := map[string]bool{}
 	for _, entry := range entries {
 		if entry.Name == "" {
-			return errors.New("skill name is empty")
+			return nil, errors.New("skill name is empty")
 		}
 		if seen[entry.Name] {
-			return fmt.Errorf("duplicate skill name %q", entry.Name)
+			return nil, fmt.Errorf("duplicate skill name %q", entry.Name)
 		}
 		seen[entry.Name] = true
 
 		src := filepath.Join(repoDir, filepath.FromSlash(entry.SourcePath))
 		dst := filepath.Join(skillRoot, entry.Name)
 		_ = os.RemoveAll(dst)
-		if err := copyDir(src, dst); err != nil {
-			return err
+		unresolved, err := copyDir(src, dst, lfsCfg)
+		if err != nil {
+			return nil, err
+		}
+		if len(unresolved) > 0 {
+			unresolvedByName[entry.Name] = unresolved
 		}
 	}
-	return nil
+	return unresolvedByName, nil
 }
 
 func writeSkillMeta(skillPath string, meta skillMeta) error {
@@ -544,18 +715,23 @@ func trimQuoted(value string) string {
 	return value
 }
 
-func copyDir(src, dst string) error {
+// copyDir mirrors src into dst, resolving any Git LFS pointer files it
+// encounters per lfsCfg.Mode. It returns the dst-relative paths of
+// pointers that could not be resolved (only possible in lfsAuto mode).
+func copyDir(src, dst string, lfsCfg lfsConfig) ([]string, error) {
 	info, err := os.Stat(src)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !info.IsDir() {
-		return fmt.Errorf("source path is not a directory: %s", src)
+		return nil, fmt.Errorf("source path is not a directory: %s", src)
 	}
 	if err := os.MkdirAll(dst, info.Mode()); err != nil {
-		return err
+		return nil, err
 	}
-	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+
+	var unresolved []string
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -578,34 +754,53 @@ func copyDir(src, dst string) error {
 		if err != nil {
 			return err
 		}
-		return copyFile(path, target, info.Mode())
+		wasUnresolved, err := copyFile(path, target, info.Mode(), lfsCfg)
+		if err != nil {
+			return err
+		}
+		if wasUnresolved {
+			unresolved = append(unresolved, filepath.ToSlash(rel))
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return unresolved, nil
 }
 
-func copyFile(src, dst string, mode fs.FileMode) error {
-	in, err := os.Open(src)
+// copyFile copies src to dst. If src is a Git LFS pointer file and
+// lfsCfg.Mode is not lfsOff, it instead fetches and writes the real
+// object content. It reports whether src was an LFS pointer that could
+// not be resolved (lfsAuto mode only; lfsRequire returns an error
+// instead).
+func copyFile(src, dst string, mode fs.FileMode, lfsCfg lfsConfig) (unresolved bool, err error) {
+	data, err := os.ReadFile(src)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	if lfsCfg.Mode != lfsOff {
+		if oid, size, ok := parseLFSPointer(data); ok {
+			resolved, fetchErr := fetchLFSObject(lfsCfg, oid, size)
+			if fetchErr == nil {
+				data = resolved
+			} else if lfsCfg.Mode == lfsRequire {
+				return false, fmt.Errorf("resolving lfs pointer %s (oid %s): %w", src, oid, fetchErr)
+			} else {
+				fmt.Fprintf(os.Stderr, "warning: leaving unresolved lfs pointer %s (oid %s): %v\n", src, oid, fetchErr)
+				unresolved = true
+			}
+		}
 	}
-	defer in.Close()
 
 	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
-		return err
+		return false, err
 	}
-	out, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = out.Close()
-	}()
-	if _, err := out.ReadFrom(in); err != nil {
-		return err
-	}
-	if err := out.Close(); err != nil {
-		return err
+	if err := os.WriteFile(dst, data, mode); err != nil {
+		return false, err
 	}
-	return os.Chmod(dst, mode)
+	return unresolved, nil
 }
 
 func safeSkillPath(rel string) (string, bool) {
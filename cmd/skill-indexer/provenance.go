@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wangxu-dev/mcp-skill-registry/internal/gitclient"
+)
+
+// fileProvenance records who last touched a single file in a skill.
+type fileProvenance struct {
+	Path       string `json:"path"`
+	CommitSHA  string `json:"commitSha"`
+	Author     string `json:"author"`
+	Email      string `json:"email"`
+	CommitTime string `json:"commitTime"`
+}
+
+// skillProvenance is the "who wrote this and when" summary attached to
+// a skill's skill.meta.json, computed from the upstream repo's history
+// rather than anything in the mirrored skill/<name> directory (which has
+// no .git of its own).
+type skillProvenance struct {
+	Files        []fileProvenance `json:"files,omitempty"`
+	LastModified string           `json:"lastModified,omitempty"`
+}
+
+// blameCachePath is where resolved (repo, head, path) blame lookups are
+// persisted so that runs which observe an unchanged repo Head don't pay
+// to walk history again.
+const blameCachePath = ".cache/skill-registry/blame.json"
+
+func loadBlameCache(path string) (map[string]gitclient.BlameInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]gitclient.BlameInfo{}, nil
+		}
+		return nil, err
+	}
+	cache := map[string]gitclient.BlameInfo{}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+func saveBlameCache(path string, cache map[string]gitclient.BlameInfo) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return os.WriteFile(path, data, 0644)
+}
+
+func blameCacheKey(repo, head, path string) string {
+	return repo + "\x00" + head + "\x00" + path
+}
+
+// computeSkillProvenance blames SKILL.md and any other files directly
+// inside the skill's source directory, using cache to skip files
+// already resolved at this exact (repo, head, path). It returns whether
+// it added anything new to cache so the caller knows to persist it.
+func computeSkillProvenance(backend gitclient.Backend, auth gitclient.Auth, cache map[string]gitclient.BlameInfo, dest, repo, head, sourcePath, skillDirAbs string) (*skillProvenance, bool, error) {
+	entries, err := os.ReadDir(skillDirAbs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	dirty := false
+	var files []fileProvenance
+	var lastModified string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		gitPath := filepath.ToSlash(filepath.Join(sourcePath, e.Name()))
+		key := blameCacheKey(repo, head, gitPath)
+
+		info, ok := cache[key]
+		if !ok {
+			blamed, err := backend.BlamePath(dest, repo, head, gitPath, auth)
+			if err != nil {
+				return nil, dirty, err
+			}
+			info = *blamed
+			cache[key] = info
+			dirty = true
+		}
+
+		files = append(files, fileProvenance{
+			Path:       e.Name(),
+			CommitSHA:  info.CommitSHA,
+			Author:     info.Author,
+			Email:      info.Email,
+			CommitTime: info.CommitTime,
+		})
+		if info.CommitTime > lastModified {
+			lastModified = info.CommitTime
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return &skillProvenance{Files: files, LastModified: lastModified}, dirty, nil
+}
+
+// generatedSkillFiles are written into skill/<name> by skill-indexer
+// itself and never exist in the upstream repo, so provenanceFromCacheOnly
+// must not try to look them up.
+var generatedSkillFiles = map[string]bool{
+	"skill.meta.json":    true,
+	"CHANGELOG.md":       true,
+	"skill.changes.json": true,
+	"skill.diff.patch":   true,
+}
+
+// provenanceFromCacheOnly rebuilds a skill's provenance entirely from
+// blameCache, without touching the source repo, for skills whose repo
+// Head hasn't changed since the last run (and so were never re-scanned
+// this time). A cache miss for any file just drops that file rather than
+// failing the whole run.
+func provenanceFromCacheOnly(cache map[string]gitclient.BlameInfo, repo, head, sourcePath, mirroredSkillDir string) *skillProvenance {
+	entries, err := os.ReadDir(mirroredSkillDir)
+	if err != nil {
+		return nil
+	}
+
+	var files []fileProvenance
+	var lastModified string
+	for _, e := range entries {
+		if e.IsDir() || generatedSkillFiles[e.Name()] {
+			continue
+		}
+		gitPath := filepath.ToSlash(filepath.Join(sourcePath, e.Name()))
+		info, ok := cache[blameCacheKey(repo, head, gitPath)]
+		if !ok {
+			continue
+		}
+		files = append(files, fileProvenance{
+			Path:       e.Name(),
+			CommitSHA:  info.CommitSHA,
+			Author:     info.Author,
+			Email:      info.Email,
+			CommitTime: info.CommitTime,
+		})
+		if info.CommitTime > lastModified {
+			lastModified = info.CommitTime
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return &skillProvenance{Files: files, LastModified: lastModified}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+
+	"github.com/wangxu-dev/mcp-skill-registry/internal/gitclient"
+)
+
+// skillRegistryAttr is the .gitattributes attribute skills can use to
+// opt in or out of the registry independent of .gitignore, e.g.
+// "docs/internal/** skill-registry=exclude".
+const skillRegistryAttr = "skill-registry"
+
+// vcsFilter answers whether a path discovered while walking a checkout
+// should be skipped, honoring the repo's own .gitignore and
+// .gitattributes files.
+type vcsFilter struct {
+	ignore gitignore.Matcher
+	attrs  []gitattributes.MatchAttribute
+}
+
+// loadVCSFilter builds a vcsFilter from dest's .gitignore and
+// .gitattributes files. A dest that isn't a git checkout (or that has
+// neither file) yields a filter that excludes nothing.
+func loadVCSFilter(dest string) (*vcsFilter, error) {
+	fsys := osfs.New(dest)
+
+	patterns, err := gitignore.ReadPatterns(fsys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading .gitignore under %q: %w", dest, err)
+	}
+
+	attrs, err := gitattributes.ReadPatterns(fsys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading .gitattributes under %q: %w", dest, err)
+	}
+
+	return &vcsFilter{
+		ignore: gitignore.NewMatcher(patterns),
+		attrs:  attrs,
+	}, nil
+}
+
+// excludes reports whether rel (slash-separated, relative to the
+// checkout root) should be skipped. A `skill-registry=include` attribute
+// always wins over .gitignore; a `skill-registry=exclude` attribute
+// wins even for paths .gitignore would otherwise keep.
+func (f *vcsFilter) excludes(rel string, isDir bool) bool {
+	if f == nil {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+
+	switch f.attrValue(parts) {
+	case "include":
+		return false
+	case "exclude":
+		return true
+	}
+
+	return f.ignore.Match(parts, isDir)
+}
+
+func (f *vcsFilter) attrValue(parts []string) string {
+	for _, m := range f.attrs {
+		if !m.Pattern.Match(parts) {
+			continue
+		}
+		for _, a := range m.Attributes {
+			if a.Name() == skillRegistryAttr && a.IsValueSet() {
+				return a.Value()
+			}
+		}
+	}
+	return ""
+}
+
+// scanSubmodules recurses into every submodule registered under dest's
+// .gitmodules and finds the SKILL.md directories it contains, tagging
+// each with the submodule's own head commit and provenance chain.
+func scanSubmodules(dest string, auth gitclient.Auth) ([]foundSkill, error) {
+	repo, err := git.PlainOpen(dest)
+	if err != nil {
+		// Not every checkout (e.g. one produced before this feature
+		// existed, or a non-git source) has a .git directory to open.
+		return nil, nil
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	subs, err := wt.Submodules()
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []foundSkill
+	for _, sub := range subs {
+		method, err := gitclient.TransportAuth(sub.Config().URL, auth)
+		if err != nil {
+			return nil, fmt.Errorf("resolving auth for submodule %q: %w", sub.Config().Path, err)
+		}
+		if err := sub.Update(&git.SubmoduleUpdateOptions{
+			Init:              true,
+			RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+			Auth:              method,
+		}); err != nil {
+			return nil, fmt.Errorf("updating submodule %q: %w", sub.Config().Path, err)
+		}
+		subRepo, err := sub.Repository()
+		if err != nil {
+			return nil, fmt.Errorf("opening submodule %q: %w", sub.Config().Path, err)
+		}
+		subHead, err := subRepo.Head()
+		if err != nil {
+			return nil, fmt.Errorf("resolving submodule %q head: %w", sub.Config().Path, err)
+		}
+
+		subDir := filepath.Join(dest, filepath.FromSlash(sub.Config().Path))
+		subFilter, err := loadVCSFilter(subDir)
+		if err != nil {
+			return nil, err
+		}
+		subSkills, err := walkSkills(subDir, subDir, buildExcludeSet(nil), subFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		prov := submoduleProvenance{
+			Path: filepath.ToSlash(sub.Config().Path),
+			Repo: sub.Config().URL,
+			Head: subHead.Hash().String(),
+		}
+		for i := range subSkills {
+			subSkills[i].SourcePath = filepath.ToSlash(filepath.Join(sub.Config().Path, subSkills[i].SourcePath))
+			subSkills[i].Head = prov.Head
+			subSkills[i].Submodules = append([]submoduleProvenance{prov}, subSkills[i].Submodules...)
+		}
+		skills = append(skills, subSkills...)
+	}
+	return skills, nil
+}
@@ -0,0 +1,108 @@
+package gitclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// BlameInfo describes the most recent commit that touched a path.
+type BlameInfo struct {
+	CommitSHA  string `json:"commitSha"`
+	Author     string `json:"author"`
+	Email      string `json:"email"`
+	CommitTime string `json:"commitTime"`
+}
+
+// BlamePath returns metadata about the most recent commit that touched
+// path within the repo checked out at dest (as of head). On a shallow
+// (--depth 1) checkout it first fetches the full history (fetch
+// --unshallow) before running git log.
+func (b *execBackend) BlamePath(dest, repo, head, path string, auth Auth) (*BlameInfo, error) {
+	if isShallow(dest, auth) {
+		// A shallow boundary commit has no parent, so `git log -- path`
+		// treats it as touching every path reachable from it, not just
+		// the ones it actually changed — log -1 would return the
+		// boundary commit for any path at all, never empty, which made
+		// the old "retry if output is empty" check never fire. There's
+		// no way to tell from the shallow history alone whether a
+		// commit's true last-touch is hidden further back, so always
+		// deepen before blaming.
+		if _, err := runGit(dest, auth, "fetch", "--unshallow"); err != nil {
+			return nil, err
+		}
+	}
+	out, err := runGit(dest, auth, "log", "-1", "--format=%H%n%an%n%ae%n%aI", "--", path)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlameLog(out)
+}
+
+// isShallow reports whether dest is a shallow clone, i.e. whether its
+// boundary commit(s) may be hiding true blame history for some paths.
+func isShallow(dest string, auth Auth) bool {
+	out, err := runGit(dest, auth, "rev-parse", "--is-shallow-repository")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+func parseBlameLog(out string) (*BlameInfo, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 4 {
+		return nil, fmt.Errorf("gitclient: unexpected git log output %q", out)
+	}
+	return &BlameInfo{CommitSHA: lines[0], Author: lines[1], Email: lines[2], CommitTime: lines[3]}, nil
+}
+
+// BlamePath is the go-git equivalent of execBackend.BlamePath: it walks
+// commit history in-process via Repository.Log, deepening the fetch
+// unconditionally first if dest is a shallow checkout.
+//
+// A shallow boundary commit has no parent, so its tree diffs against
+// nil and every path in it looks "added" — logPath's walk would return
+// that single commit for any path at all and never the io.EOF a fully
+// unreachable path would otherwise produce, so waiting for io.EOF before
+// deepening (as execBackend used to) can never fire here either.
+func (b *goGitBackend) BlamePath(dest, repo, head, path string, auth Auth) (*BlameInfo, error) {
+	repository, err := git.PlainOpen(dest)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", dest, err)
+	}
+
+	shallow, err := repository.Storer.Shallow()
+	if err != nil {
+		return nil, fmt.Errorf("checking shallow state of %q: %w", dest, err)
+	}
+	if len(shallow) > 0 {
+		method, authErr := TransportAuth(repo, auth)
+		if authErr != nil {
+			return nil, authErr
+		}
+		fetchErr := repository.Fetch(&git.FetchOptions{RemoteName: "origin", Auth: method, Depth: 0})
+		if fetchErr != nil && fetchErr != git.NoErrAlreadyUpToDate {
+			return nil, fmt.Errorf("deepening history to blame %q: %w", path, fetchErr)
+		}
+	}
+
+	return logPath(repository, head, path)
+}
+
+func logPath(repository *git.Repository, head, path string) (*BlameInfo, error) {
+	commitIter, err := repository.Log(&git.LogOptions{From: plumbing.NewHash(head), FileName: &path})
+	if err != nil {
+		return nil, err
+	}
+	commit, err := commitIter.Next()
+	if err != nil {
+		return nil, err
+	}
+	return &BlameInfo{
+		CommitSHA:  commit.Hash.String(),
+		Author:     commit.Author.Name,
+		Email:      commit.Author.Email,
+		CommitTime: commit.Author.When.UTC().Format(time.RFC3339),
+	}, nil
+}
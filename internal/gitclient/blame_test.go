@@ -0,0 +1,99 @@
+package gitclient
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not found on PATH")
+	}
+}
+
+// runGitT is a test helper that fails the test on error instead of
+// returning one, for building fixture repos.
+func runGitT(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := runGit(dir, Auth{}, args...)
+	if err != nil {
+		t.Fatalf("git %s: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// newTwoCommitRepo creates a repo with old.txt added in the first commit
+// and a second, unrelated commit that only touches new.txt, returning
+// the repo's path.
+func newTwoCommitRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	runGitT(t, dir, "init", "-q", "-b", "main")
+	runGitT(t, dir, "config", "user.email", "test@example.com")
+	runGitT(t, dir, "config", "user.name", "Test")
+
+	if err := os.WriteFile(filepath.Join(dir, "old.txt"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitT(t, dir, "add", "old.txt")
+	runGitT(t, dir, "commit", "-q", "-m", "add old.txt")
+
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitT(t, dir, "add", "new.txt")
+	runGitT(t, dir, "commit", "-q", "-m", "add new.txt")
+
+	return dir
+}
+
+func TestIsShallow(t *testing.T) {
+	requireGit(t)
+	origin := newTwoCommitRepo(t)
+
+	full := t.TempDir()
+	runGitT(t, "", "clone", "-q", origin, full)
+	if isShallow(full, Auth{}) {
+		t.Errorf("full clone reported as shallow")
+	}
+
+	shallow := t.TempDir()
+	runGitT(t, "", "clone", "-q", "--depth", "1", "file://"+origin, shallow)
+	if !isShallow(shallow, Auth{}) {
+		t.Errorf("depth-1 clone not detected as shallow")
+	}
+}
+
+// TestBlamePathDeepensShallowHistory exercises exactly the failure mode
+// chunk0-5's review comment described: on a --depth 1 clone, the boundary
+// commit has no parent, so a naive `git log -1 -- old.txt` returns the
+// boundary (newest) commit instead of old.txt's real last-touch commit.
+// BlamePath must deepen first and report the correct one.
+func TestBlamePathDeepensShallowHistory(t *testing.T) {
+	requireGit(t)
+	origin := newTwoCommitRepo(t)
+	oldCommit := strings.TrimSpace(runGitT(t, origin, "log", "-1", "--format=%H", "--", "old.txt"))
+	newCommit := strings.TrimSpace(runGitT(t, origin, "rev-parse", "HEAD"))
+	if oldCommit == newCommit {
+		t.Fatalf("fixture is broken: old.txt's last commit should differ from HEAD")
+	}
+
+	dest := t.TempDir()
+	runGitT(t, "", "clone", "-q", "--depth", "1", "file://"+origin, dest)
+	if !isShallow(dest, Auth{}) {
+		t.Fatalf("fixture clone is not actually shallow; test would not exercise the deepen path")
+	}
+
+	backend := &execBackend{}
+	info, err := backend.BlamePath(dest, origin, newCommit, "old.txt", Auth{})
+	if err != nil {
+		t.Fatalf("BlamePath: %v", err)
+	}
+	if info.CommitSHA != oldCommit {
+		t.Errorf("BlamePath attributed old.txt to %s, want its real last-touch commit %s", info.CommitSHA, oldCommit)
+	}
+}
@@ -0,0 +1,175 @@
+package gitclient
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// FileDiff summarizes the change to a single file within a Diff result.
+type FileDiff struct {
+	Path    string `json:"path"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+}
+
+// DiffResult is a unified diff between two commits, scoped to a path
+// prefix, along with per-file add/remove counts.
+type DiffResult struct {
+	Unified string
+	Files   []FileDiff
+}
+
+// Diff returns the unified diff between fromHead and toHead, restricted
+// to paths under pathPrefix, fetching fromHead into the checkout at dest
+// first if it isn't already present there (e.g. because dest only holds
+// a shallow, single-commit clone). The diff text is truncated to
+// maxDiffBytes when positive.
+func (b *execBackend) Diff(dest, repo, fromHead, toHead, pathPrefix string, auth Auth, maxDiffBytes int) (*DiffResult, error) {
+	if _, err := runGit(dest, auth, "cat-file", "-e", fromHead+"^{commit}"); err != nil {
+		if _, err := runGit(dest, auth, "fetch", "--depth", "1", repo, fromHead); err != nil {
+			return nil, fmt.Errorf("fetching old commit %s: %w", fromHead, err)
+		}
+	}
+
+	numstat, err := runGit(dest, auth, "diff", "--numstat", fromHead, toHead, "--", pathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	unified, err := runGit(dest, auth, "diff", "--unified=3", fromHead, toHead, "--", pathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffResult{
+		Unified: truncateDiff(unified, maxDiffBytes),
+		Files:   parseNumstat(numstat),
+	}, nil
+}
+
+func parseNumstat(out string) []FileDiff {
+	var files []FileDiff
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0])
+		removed, _ := strconv.Atoi(fields[1])
+		files = append(files, FileDiff{Path: fields[2], Added: added, Removed: removed})
+	}
+	return files
+}
+
+func truncateDiff(unified string, maxDiffBytes int) string {
+	if maxDiffBytes <= 0 || len(unified) <= maxDiffBytes {
+		return unified
+	}
+	return unified[:maxDiffBytes] + "\n... (diff truncated)\n"
+}
+
+// Diff is the go-git equivalent of execBackend.Diff: it walks the two
+// commits' trees in-process instead of shelling out, fetching fromHead
+// first if dest's history doesn't already reach it.
+func (b *goGitBackend) Diff(dest, repo, fromHead, toHead, pathPrefix string, auth Auth, maxDiffBytes int) (*DiffResult, error) {
+	repository, err := git.PlainOpen(dest)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", dest, err)
+	}
+
+	fromHash := plumbing.NewHash(fromHead)
+	if _, err := repository.CommitObject(fromHash); err != nil {
+		method, authErr := TransportAuth(repo, auth)
+		if authErr != nil {
+			return nil, authErr
+		}
+		refSpec := config.RefSpec(fromHead + ":" + fromHead)
+		fetchErr := repository.Fetch(&git.FetchOptions{RemoteName: "origin", Auth: method, RefSpecs: []config.RefSpec{refSpec}, Depth: 1})
+		if fetchErr != nil && fetchErr != git.NoErrAlreadyUpToDate {
+			return nil, fmt.Errorf("fetching old commit %s: %w", fromHead, fetchErr)
+		}
+	}
+
+	fromCommit, err := repository.CommitObject(fromHash)
+	if err != nil {
+		return nil, fmt.Errorf("resolving old commit %s: %w", fromHead, err)
+	}
+	toCommit, err := repository.CommitObject(plumbing.NewHash(toHead))
+	if err != nil {
+		return nil, fmt.Errorf("resolving new commit %s: %w", toHead, err)
+	}
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := fromTree.Diff(toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := diff.NewUnifiedEncoder(&buf, diff.DefaultContextLines)
+	var files []FileDiff
+	for _, c := range changes {
+		from, to, err := c.Files()
+		if err != nil {
+			return nil, err
+		}
+		path := changePath(from, to)
+		if !withinPathPrefix(path, pathPrefix) {
+			continue
+		}
+		patch, err := c.Patch()
+		if err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(patch); err != nil {
+			return nil, err
+		}
+		for _, stat := range patch.Stats() {
+			files = append(files, FileDiff{Path: stat.Name, Added: stat.Addition, Removed: stat.Deletion})
+		}
+	}
+
+	return &DiffResult{
+		Unified: truncateDiff(buf.String(), maxDiffBytes),
+		Files:   files,
+	}, nil
+}
+
+// withinPathPrefix reports whether path is pathPrefix itself or lives
+// under it, matching on path-separator boundaries the way the exec
+// backend's `git diff -- pathPrefix` pathspec naturally scopes things
+// (so "skills/foobar" isn't treated as being under "skills/foo").
+func withinPathPrefix(path, pathPrefix string) bool {
+	if pathPrefix == "" || path == pathPrefix {
+		return true
+	}
+	return strings.HasPrefix(path, pathPrefix+"/")
+}
+
+func changePath(from, to *object.File) string {
+	if to != nil {
+		return to.Name
+	}
+	if from != nil {
+		return from.Name
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package gitclient
+
+import "testing"
+
+func TestWithinPathPrefix(t *testing.T) {
+	tests := []struct {
+		path       string
+		pathPrefix string
+		want       bool
+	}{
+		{"skills/foo/SKILL.md", "skills/foo", true},
+		{"skills/foo", "skills/foo", true},
+		{"skills/foobar/SKILL.md", "skills/foo", false},
+		{"skills/foobar", "skills/foo", false},
+		{"skills/bar/SKILL.md", "skills/foo", false},
+		{"anything", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := withinPathPrefix(tt.path, tt.pathPrefix); got != tt.want {
+			t.Errorf("withinPathPrefix(%q, %q) = %v, want %v", tt.path, tt.pathPrefix, got, tt.want)
+		}
+	}
+}
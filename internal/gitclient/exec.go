@@ -0,0 +1,82 @@
+package gitclient
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// execBackend shells out to the system git binary. It is the long-time
+// default and is kept around for environments where go-git's auth
+// handling doesn't cover a remote's quirks.
+//
+// Neither backend does a partial (blobless/treeless) fetch: walkSkills
+// needs to read arbitrary files across the whole checked-out tree to
+// find SKILL.md directories, and a non-sparse `git clone`/checkout of
+// HEAD has to materialize every blob in that tree regardless of fetch
+// filters, so filter=blob:none wouldn't actually reduce what's
+// downloaded here. Depth-1 cloning is what supplies the bandwidth win
+// over a full-history clone.
+type execBackend struct{}
+
+func newExecBackend() *execBackend {
+	return &execBackend{}
+}
+
+func (b *execBackend) RemoteHead(repo, ref string, auth Auth) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	out, err := runGit("", auth, "ls-remote", repo, ref)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%w: git ls-remote returned no data for %q %q", ErrNoSuchRef, repo, ref)
+	}
+	return fields[0], nil
+}
+
+func (b *execBackend) Checkout(repo string, opts CloneOptions) (string, error) {
+	_ = os.RemoveAll(opts.Dest)
+
+	cloneArgs := []string{"clone", "--depth", "1"}
+	if opts.Branch != "" {
+		cloneArgs = append(cloneArgs, "--branch", opts.Branch)
+	}
+	cloneArgs = append(cloneArgs, repo, opts.Dest)
+	if _, err := runGit("", opts.Auth, cloneArgs...); err != nil {
+		return "", err
+	}
+
+	head, err := runGit(opts.Dest, opts.Auth, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(head), nil
+}
+
+func runGit(dir string, auth Auth, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if auth.SSHKeyPath != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes", auth.SSHKeyPath))
+	}
+	if auth.HTTPToken != "" {
+		cmd.Args = append(cmd.Args[:1], append([]string{"-c", "http.extraHeader=Authorization: Bearer " + auth.HTTPToken}, cmd.Args[1:]...)...)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
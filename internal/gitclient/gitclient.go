@@ -0,0 +1,93 @@
+// Package gitclient abstracts the git operations skill-indexer needs
+// (resolving a remote HEAD, materializing a repo's tree on disk) behind
+// a small interface so the indexer can run either by shelling out to the
+// system git binary or by driving go-git in-process.
+package gitclient
+
+import "errors"
+
+// ErrNoSuchRef is returned by Backend.RemoteHead when the requested ref
+// does not exist on the remote.
+var ErrNoSuchRef = errors.New("gitclient: no such ref on remote")
+
+// Auth carries the credentials a Backend should use when talking to a
+// remote. Either field may be empty; backends fall back to ambient
+// credentials (e.g. an SSH agent) when Auth is the zero value.
+type Auth struct {
+	// SSHKeyPath, when set, is a path to a private key file used for
+	// ssh:// and git@ remotes. If empty, backends should try
+	// SSH_AUTH_SOCK first.
+	SSHKeyPath string
+
+	// HTTPToken, when set, is sent as a bearer/basic credential for
+	// https:// remotes (populated from SKILL_REGISTRY_HTTP_TOKEN).
+	HTTPToken string
+}
+
+// CloneOptions controls how Backend.Checkout materializes a remote repo.
+type CloneOptions struct {
+	// Branch is the ref to check out. Empty means the remote's default
+	// branch (its HEAD).
+	Branch string
+
+	// Dest is the directory the working tree is written to. Backends
+	// that support reusing an existing checkout (keyed by remote URL)
+	// may fetch into Dest instead of recreating it from scratch.
+	Dest string
+
+	Auth Auth
+}
+
+// Backend performs the git operations skill-indexer needs against a
+// remote repository. The "exec" backend shells out to the system git
+// binary; the "go-git" backend drives github.com/go-git/go-git/v5
+// in-process instead, so skill-indexer can run without a git binary on
+// PATH.
+type Backend interface {
+	// RemoteHead resolves ref (or the remote's default branch when ref
+	// is empty) to a commit SHA without fetching any objects.
+	RemoteHead(repo, ref string, auth Auth) (string, error)
+
+	// Checkout materializes repo's working tree at opts.Dest and
+	// returns the checked-out commit SHA.
+	Checkout(repo string, opts CloneOptions) (string, error)
+
+	// Diff returns the unified diff between fromHead and toHead,
+	// restricted to paths under pathPrefix, using dest (an existing
+	// checkout of repo) to source the objects and fetching fromHead
+	// into it first if it isn't already present. maxDiffBytes truncates
+	// the unified text when positive; 0 means unlimited.
+	Diff(dest, repo, fromHead, toHead, pathPrefix string, auth Auth, maxDiffBytes int) (*DiffResult, error)
+
+	// BlamePath returns metadata about the most recent commit (at or
+	// before head) that touched path within the repo checked out at
+	// dest, first deepening dest's history whenever it is a shallow
+	// clone.
+	BlamePath(dest, repo, head, path string, auth Auth) (*BlameInfo, error)
+}
+
+// Name identifies a Backend implementation, selectable via the
+// --git-backend flag.
+type Name string
+
+const (
+	// Exec shells out to the system git binary. It is the default and
+	// matches skill-indexer's historical behavior.
+	Exec Name = "exec"
+
+	// GoGit drives go-git in-process, avoiding the system git
+	// dependency.
+	GoGit Name = "go-git"
+)
+
+// New constructs the Backend selected by name.
+func New(name Name) (Backend, error) {
+	switch name {
+	case "", Exec:
+		return newExecBackend(), nil
+	case GoGit:
+		return newGoGitBackend(), nil
+	default:
+		return nil, errors.New("gitclient: unknown backend " + string(name))
+	}
+}